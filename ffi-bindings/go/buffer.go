@@ -0,0 +1,140 @@
+package zenith
+
+/*
+#include "../../zenith_core.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unsafe"
+)
+
+// SharedBuffer is a slab of memory allocated by the Rust engine and mapped
+// directly into Go, avoiding the copy that PushEvent and LoadPlugin pay to
+// cross the CGo boundary. Data is backed by C memory: write into it, then
+// call Commit to hand the written bytes to the engine, or Release to return
+// the slab without committing.
+type SharedBuffer struct {
+	client *Client
+	id     uint64
+	// Data is the mapped slab: sized to the capacity requested from
+	// AcquireBuffer, or to the output's length for a buffer from NextOutput.
+	// Write directly into it before calling Commit.
+	Data []byte
+
+	done bool
+}
+
+// AcquireBuffer maps a Rust-allocated slab of size bytes into Go. The caller
+// writes into buf.Data and calls buf.Commit to hand the bytes to the engine
+// without another copy, or buf.Release to give the slab back unused.
+func (c *Client) AcquireBuffer(ctx context.Context, size int) (*SharedBuffer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if size <= 0 {
+		return nil, errors.New("size must be positive")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return nil, errors.New("client is closed")
+	}
+
+	var cPtr *C.uint8_t
+	var id C.uint64_t
+	ret := C.zenith_acquire_buffer(c.enginePtr, C.size_t(size), &cPtr, &id)
+	if ret != C.ZENITH_OK {
+		return nil, fmt.Errorf("failed to acquire buffer: error code %d", ret)
+	}
+
+	return &SharedBuffer{
+		client: c,
+		id:     uint64(id),
+		Data:   unsafe.Slice((*byte)(unsafe.Pointer(cPtr)), size),
+	}, nil
+}
+
+// Commit hands the first n bytes of Data to the engine as a single event
+// payload, transferring ownership of the slab without copying it. Only one
+// of Commit or Release may succeed on a buffer, and only once.
+func (b *SharedBuffer) Commit(n int) error {
+	if b.done {
+		return errors.New("buffer already committed or released")
+	}
+	if n < 0 || n > len(b.Data) {
+		return fmt.Errorf("commit length %d out of range [0, %d]", n, len(b.Data))
+	}
+
+	b.client.mu.RLock()
+	defer b.client.mu.RUnlock()
+	if b.client.closed {
+		return errors.New("client is closed")
+	}
+
+	ret := C.zenith_commit_buffer(b.client.enginePtr, C.uint64_t(b.id), C.size_t(n))
+	if ret != C.ZENITH_OK {
+		return fmt.Errorf("failed to commit buffer: error code %d", ret)
+	}
+
+	b.done = true
+	return nil
+}
+
+// Release returns an uncommitted slab to the engine's free-list without
+// copying its contents anywhere. Only one of Commit or Release may succeed
+// on a buffer, and only once.
+func (b *SharedBuffer) Release() error {
+	if b.done {
+		return errors.New("buffer already committed or released")
+	}
+
+	b.client.mu.RLock()
+	defer b.client.mu.RUnlock()
+	if b.client.closed {
+		return errors.New("client is closed")
+	}
+
+	ret := C.zenith_release_buffer(b.client.enginePtr, C.uint64_t(b.id))
+	if ret != C.ZENITH_OK {
+		return fmt.Errorf("failed to release buffer: error code %d", ret)
+	}
+
+	b.done = true
+	return nil
+}
+
+// NextOutput returns the next plugin output slab in place, without copying
+// it out of the engine's memory. Call buf.Release once done reading it to
+// return the slab to the engine's free-list.
+func (c *Client) NextOutput(ctx context.Context) (*SharedBuffer, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return nil, errors.New("client is closed")
+	}
+
+	var cPtr *C.uint8_t
+	var cLen C.size_t
+	var id C.uint64_t
+	ret := c.withCancel(ctx, func(opID C.uint64_t) C.int {
+		return C.zenith_next_output(c.enginePtr, opID, &cPtr, &cLen, &id)
+	})
+	if ret != C.ZENITH_OK {
+		return nil, fmt.Errorf("failed to read next output: error code %d", ret)
+	}
+
+	return &SharedBuffer{
+		client: c,
+		id:     uint64(id),
+		Data:   unsafe.Slice((*byte)(unsafe.Pointer(cPtr)), int(cLen)),
+	}, nil
+}
@@ -0,0 +1,111 @@
+package zenith
+
+import (
+	"context"
+	"testing"
+)
+
+func TestAcquireBufferRejectsNonPositiveSize(t *testing.T) {
+	client := newTestClient(t)
+
+	if _, err := client.AcquireBuffer(context.Background(), 0); err == nil {
+		t.Error("expected error for non-positive size")
+	}
+}
+
+func TestCommitThenReleaseIsRejected(t *testing.T) {
+	client := newTestClient(t)
+
+	buf, err := client.AcquireBuffer(context.Background(), 64)
+	if err != nil {
+		t.Fatalf("AcquireBuffer failed: %v", err)
+	}
+
+	if err := buf.Commit(64); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	if err := buf.Release(); err == nil {
+		t.Error("expected error releasing an already-committed buffer")
+	}
+}
+
+func TestNextOutputReadsCommittedEventInPlace(t *testing.T) {
+	client := newTestClient(t)
+
+	in, err := client.AcquireBuffer(context.Background(), 64)
+	if err != nil {
+		t.Fatalf("AcquireBuffer failed: %v", err)
+	}
+	n := copy(in.Data, "plugin output")
+	if err := in.Commit(n); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	out, err := client.NextOutput(context.Background())
+	if err != nil {
+		t.Fatalf("NextOutput failed: %v", err)
+	}
+	if string(out.Data) != "plugin output" {
+		t.Errorf("expected %q, got %q", "plugin output", out.Data)
+	}
+
+	if err := out.Release(); err != nil {
+		t.Errorf("Release failed: %v", err)
+	}
+}
+
+func TestNextOutputRejectsAfterClose(t *testing.T) {
+	client := newTestClient(t)
+	client.Close(context.Background())
+
+	if _, err := client.NextOutput(context.Background()); err == nil {
+		t.Error("expected error after client is closed")
+	}
+}
+
+// BenchmarkPushEventCopying exercises the existing copying ingest path: the
+// payload crosses the CGo boundary on every call.
+func BenchmarkPushEventCopying(b *testing.B) {
+	client, err := NewClient(context.Background(), 1<<20)
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close(context.Background())
+
+	payload := make([]byte, 4096)
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := client.PushEvent(ctx, "bench", payload); err != nil {
+			b.Fatalf("PushEvent failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkPushEventZeroCopy exercises the SharedBuffer path: the payload is
+// written directly into engine-owned memory and committed without a copy.
+func BenchmarkPushEventZeroCopy(b *testing.B) {
+	client, err := NewClient(context.Background(), 1<<20)
+	if err != nil {
+		b.Fatalf("Failed to create client: %v", err)
+	}
+	defer client.Close(context.Background())
+
+	ctx := context.Background()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, err := client.AcquireBuffer(ctx, 4096)
+		if err != nil {
+			b.Fatalf("AcquireBuffer failed: %v", err)
+		}
+		for j := range buf.Data {
+			buf.Data[j] = byte(j)
+		}
+		if err := buf.Commit(len(buf.Data)); err != nil {
+			b.Fatalf("Commit failed: %v", err)
+		}
+	}
+}
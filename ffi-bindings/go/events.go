@@ -0,0 +1,400 @@
+package zenith
+
+/*
+#include "../../zenith_core.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Event represents a single item flowing through the engine, either pushed
+// in by a producer or delivered to a subscriber.
+type Event struct {
+	Topic   string
+	Payload []byte
+}
+
+// BackpressureMode controls what Subscribe does when a subscriber's channel
+// is full.
+type BackpressureMode int
+
+const (
+	// BackpressureBlock blocks the drain loop until the subscriber keeps up.
+	// A slow subscriber can stall delivery to every other subscriber.
+	BackpressureBlock BackpressureMode = iota
+	// BackpressureDropOldest discards the oldest buffered event to make room
+	// for the new one, so the drain loop never blocks on a slow subscriber.
+	BackpressureDropOldest
+)
+
+// SubscribeOption configures a single Subscribe call.
+type SubscribeOption func(*subscribeConfig)
+
+type subscribeConfig struct {
+	mode       BackpressureMode
+	bufferSize int
+}
+
+// WithBackpressure selects how a subscription behaves when its channel
+// buffer is full. The default is BackpressureBlock.
+func WithBackpressure(mode BackpressureMode) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.mode = mode
+	}
+}
+
+// WithChannelBuffer sets the size of the channel returned by Subscribe.
+// The default is 64.
+func WithChannelBuffer(n int) SubscribeOption {
+	return func(c *subscribeConfig) {
+		c.bufferSize = n
+	}
+}
+
+// subscription is one registered consumer of a topic. mu serializes sends
+// against close so dispatch never sends on a channel that removeSubscription
+// has already closed.
+type subscription struct {
+	ch   chan Event
+	mode BackpressureMode
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// PushEvent pushes a single event onto the given topic.
+func (c *Client) PushEvent(ctx context.Context, topic string, payload []byte) (err error) {
+	ctx, span := c.tracerOrDefault().Start(ctx, "zenith.push_event", trace.WithAttributes(
+		attribute.String("zenith.topic", topic),
+		attribute.Int("zenith.event.bytes", len(payload)),
+	))
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+	if topic == "" {
+		return errors.New("topic must not be empty")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errors.New("client is closed")
+	}
+
+	cTopic := C.CString(topic)
+	defer C.free(unsafe.Pointer(cTopic))
+
+	var cPayload *C.uint8_t
+	if len(payload) > 0 {
+		cPayload = (*C.uint8_t)(unsafe.Pointer(&payload[0]))
+	}
+
+	ret := C.zenith_push_event(c.enginePtr, cTopic, C.size_t(len(topic)), cPayload, C.size_t(len(payload)))
+	if ret != C.ZENITH_OK {
+		return fmt.Errorf("failed to push event: error code %d", ret)
+	}
+
+	return nil
+}
+
+// PushBatch pushes many events in a single CGo transition. Topic and payload
+// bytes are packed into two contiguous buffers with a parallel index array of
+// (offset, len) pairs, so the Rust side can walk the batch without any
+// per-event marshaling back across the boundary.
+func (c *Client) PushBatch(ctx context.Context, events []Event) (accepted int, err error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return 0, errors.New("client is closed")
+	}
+
+	var topics, payloads []byte
+	index := make([]C.ZenithBatchEntry, len(events))
+	for i, ev := range events {
+		index[i] = C.ZenithBatchEntry{
+			topic_offset:   C.size_t(len(topics)),
+			topic_len:      C.size_t(len(ev.Topic)),
+			payload_offset: C.size_t(len(payloads)),
+			payload_len:    C.size_t(len(ev.Payload)),
+		}
+		topics = append(topics, ev.Topic...)
+		payloads = append(payloads, ev.Payload...)
+	}
+
+	var cTopics, cPayloads *C.uint8_t
+	if len(topics) > 0 {
+		cTopics = (*C.uint8_t)(unsafe.Pointer(&topics[0]))
+	}
+	if len(payloads) > 0 {
+		cPayloads = (*C.uint8_t)(unsafe.Pointer(&payloads[0]))
+	}
+
+	var cAccepted C.size_t
+	ret := c.withCancel(ctx, func(opID C.uint64_t) C.int {
+		return C.zenith_push_batch(
+			c.enginePtr, opID,
+			cTopics, cPayloads,
+			&index[0],
+			C.size_t(len(index)),
+			&cAccepted,
+		)
+	})
+	if ret != C.ZENITH_OK {
+		return int(cAccepted), fmt.Errorf("failed to push batch: error code %d", ret)
+	}
+
+	return int(cAccepted), nil
+}
+
+// Subscribe returns a channel of events published on topic. A single
+// background goroutine drains the engine's shared ring buffer and fans
+// events out to every registered subscriber; slow subscribers are handled
+// per the chosen BackpressureMode. A BackpressureDropOldest subscriber never
+// stalls the drain loop; a BackpressureBlock subscriber (the default) that
+// falls behind stalls delivery to every subscriber. The returned channel is
+// closed when ctx is done or the client is closed.
+func (c *Client) Subscribe(ctx context.Context, topic string, opts ...SubscribeOption) (<-chan Event, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if topic == "" {
+		return nil, errors.New("topic must not be empty")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return nil, errors.New("client is closed")
+	}
+
+	cfg := subscribeConfig{mode: BackpressureBlock, bufferSize: 64}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	sub := &subscription{ch: make(chan Event, cfg.bufferSize), mode: cfg.mode}
+
+	c.subsMu.Lock()
+	if c.subs == nil {
+		c.subs = make(map[string][]*subscription)
+	}
+	c.subs[topic] = append(c.subs[topic], sub)
+	c.subsMu.Unlock()
+
+	if err := c.ensureDrainLoop(ctx); err != nil {
+		c.removeSubscription(topic, sub)
+		return nil, err
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-c.closeSignal:
+		}
+		c.removeSubscription(topic, sub)
+	}()
+
+	return sub.ch, nil
+}
+
+// ensureDrainLoop starts the single background goroutine that polls the
+// engine's ring buffer, the first time any caller subscribes.
+func (c *Client) ensureDrainLoop(ctx context.Context) error {
+	var startErr error
+	c.drainOnce.Do(func() {
+		ret := c.withCancel(ctx, func(opID C.uint64_t) C.int {
+			return C.zenith_subscribe(c.enginePtr, opID)
+		})
+		if ret != C.ZENITH_OK {
+			startErr = fmt.Errorf("failed to start subscription: error code %d", ret)
+			return
+		}
+		c.subsMu.Lock()
+		defer c.subsMu.Unlock()
+		select {
+		case <-c.closeSignal:
+			// Close has begun and stopDrainLoop may already have looked for
+			// a loop to stop; starting one now would outlive the engine.
+			startErr = errors.New("client is closed")
+			return
+		default:
+		}
+		c.drainStop = make(chan struct{})
+		c.drainDone = make(chan struct{})
+		go c.drainLoop(c.drainStop, c.drainDone)
+	})
+	return startErr
+}
+
+// drainLoop polls the Rust-side ring buffer and fans each event out to every
+// subscriber registered for its topic. It closes done right before returning
+// so stopDrainLoop can block until the last C.zenith_poll_event call has
+// actually returned, rather than racing Close's zenith_free against a poll
+// still in flight on enginePtr.
+func (c *Client) drainLoop(stop, done chan struct{}) {
+	defer close(done)
+
+	var cEvent C.ZenithEvent
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+
+		ret := C.zenith_poll_event(c.enginePtr, C.int(100), &cEvent)
+		switch ret {
+		case C.ZENITH_AGAIN:
+			continue
+		case C.ZENITH_OK:
+			ev := Event{
+				Topic:   C.GoStringN(cEvent.topic, C.int(cEvent.topic_len)),
+				Payload: C.GoBytes(unsafe.Pointer(cEvent.payload), C.int(cEvent.payload_len)),
+			}
+			C.zenith_free_event(&cEvent)
+			if !c.dispatch(ev, stop) {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+// dispatch fans ev out to every subscriber registered for its topic. A
+// BackpressureBlock subscriber can make this block indefinitely if its
+// consumer has stopped reading, so every blocking send also watches stop;
+// dispatch gives up and reports false the moment stop fires, letting
+// drainLoop exit instead of wedging the whole client behind a dead
+// consumer.
+func (c *Client) dispatch(ev Event, stop <-chan struct{}) bool {
+	c.subsMu.Lock()
+	subs := c.subs[ev.Topic]
+	c.subsMu.Unlock()
+
+	for _, sub := range subs {
+		sub.mu.Lock()
+		if sub.closed {
+			sub.mu.Unlock()
+			continue
+		}
+
+		switch sub.mode {
+		case BackpressureDropOldest:
+			select {
+			case sub.ch <- ev:
+			default:
+				select {
+				case <-sub.ch:
+				default:
+				}
+				select {
+				case sub.ch <- ev:
+				default:
+				}
+			}
+		default: // BackpressureBlock
+			select {
+			case sub.ch <- ev:
+			case <-stop:
+				sub.mu.Unlock()
+				return false
+			}
+		}
+		sub.mu.Unlock()
+	}
+
+	return true
+}
+
+// removeSubscription unregisters target from topic and closes its channel.
+// The close happens under sub.mu so a dispatch in flight for target either
+// finishes its send before the close, or observes sub.closed and skips it -
+// never both, which is what would panic with a send on a closed channel.
+func (c *Client) removeSubscription(topic string, target *subscription) {
+	c.subsMu.Lock()
+	subs := c.subs[topic]
+	for i, sub := range subs {
+		if sub == target {
+			c.subs[topic] = append(subs[:i], subs[i+1:]...)
+			break
+		}
+	}
+	c.subsMu.Unlock()
+
+	target.mu.Lock()
+	if !target.closed {
+		target.closed = true
+		close(target.ch)
+	}
+	target.mu.Unlock()
+}
+
+// closeAllSubscriptions closes every currently registered subscription's
+// channel, fulfilling Subscribe's documented contract that the returned
+// channel closes when the client is closed. Called from Close after
+// stopDrainLoop and before Close takes c.mu for writing, so no dispatch is
+// still in flight to race the close.
+func (c *Client) closeAllSubscriptions() {
+	c.subsMu.Lock()
+	subs := c.subs
+	c.subs = nil
+	c.subsMu.Unlock()
+
+	for _, topicSubs := range subs {
+		for _, sub := range topicSubs {
+			sub.mu.Lock()
+			if !sub.closed {
+				sub.closed = true
+				close(sub.ch)
+			}
+			sub.mu.Unlock()
+		}
+	}
+}
+
+// stopDrainLoop signals the background drain goroutine to exit and blocks
+// until it has actually done so. It is a no-op if no one has ever
+// subscribed. Callers must wait for this to return before freeing
+// enginePtr: the loop calls C.zenith_poll_event on it from a separate
+// goroutine, and closing the stop channel only asks it to exit on its next
+// iteration.
+func (c *Client) stopDrainLoop() {
+	c.subsMu.Lock()
+	stop, done := c.drainStop, c.drainDone
+	c.subsMu.Unlock()
+	if stop == nil {
+		return
+	}
+
+	select {
+	case <-stop:
+	default:
+		close(stop)
+	}
+	<-done
+}
@@ -0,0 +1,111 @@
+package zenith
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestPushEventRejectsEmptyTopic(t *testing.T) {
+	client := newTestClient(t)
+
+	if err := client.PushEvent(context.Background(), "", []byte("payload")); err == nil {
+		t.Error("expected error for empty topic")
+	}
+}
+
+func TestPushBatchEmptyIsNoop(t *testing.T) {
+	client := newTestClient(t)
+
+	accepted, err := client.PushBatch(context.Background(), nil)
+	if err != nil {
+		t.Errorf("PushBatch with no events should not error: %v", err)
+	}
+	if accepted != 0 {
+		t.Errorf("expected 0 accepted, got %d", accepted)
+	}
+}
+
+func TestPushBatchDeliversToSubscriber(t *testing.T) {
+	client := newTestClient(t)
+
+	ch, err := client.Subscribe(context.Background(), "orders")
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	events := []Event{
+		{Topic: "orders", Payload: []byte("first")},
+		{Topic: "orders", Payload: []byte("second")},
+	}
+	accepted, err := client.PushBatch(context.Background(), events)
+	if err != nil {
+		t.Fatalf("PushBatch failed: %v", err)
+	}
+	if accepted != len(events) {
+		t.Errorf("expected %d accepted, got %d", len(events), accepted)
+	}
+
+	for _, want := range events {
+		select {
+		case got := <-ch:
+			if string(got.Payload) != string(want.Payload) {
+				t.Errorf("expected payload %q, got %q", want.Payload, got.Payload)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("timed out waiting for event %q", want.Payload)
+		}
+	}
+}
+
+func TestSubscribeDropOldestDiscardsUnderBackpressure(t *testing.T) {
+	client := newTestClient(t)
+
+	ch, err := client.Subscribe(context.Background(), "orders",
+		WithBackpressure(BackpressureDropOldest), WithChannelBuffer(1))
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	events := []Event{
+		{Topic: "orders", Payload: []byte("stale")},
+		{Topic: "orders", Payload: []byte("fresh")},
+	}
+	if _, err := client.PushBatch(context.Background(), events); err != nil {
+		t.Fatalf("PushBatch failed: %v", err)
+	}
+
+	select {
+	case got := <-ch:
+		if string(got.Payload) != "fresh" {
+			t.Errorf("expected the stale event to be dropped, got %q", got.Payload)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+}
+
+func TestSubscribeClosesOnContextCancel(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := client.Subscribe(ctx, "orders", WithBackpressure(BackpressureDropOldest))
+	if err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	cancel()
+
+	if _, ok := <-ch; ok {
+		t.Error("expected channel to be closed after context cancellation")
+	}
+}
+
+func TestPushEventRejectsAfterClose(t *testing.T) {
+	client := newTestClient(t)
+	client.Close(context.Background())
+
+	if err := client.PushEvent(context.Background(), "orders", nil); err == nil {
+		t.Error("expected error after client is closed")
+	}
+}
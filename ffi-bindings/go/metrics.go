@@ -0,0 +1,125 @@
+package zenith
+
+/*
+#include "../../zenith_core.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const metricsNamespace = "zenith"
+
+var (
+	pluginLatencyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "plugin", "event_latency_seconds"),
+		"Per-plugin event processing latency.",
+		[]string{"plugin_id", "plugin_name"}, nil,
+	)
+	pluginFuelDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "plugin", "fuel_consumed_total"),
+		"Cumulative WASM fuel consumed by a plugin.",
+		[]string{"plugin_id", "plugin_name"}, nil,
+	)
+	bufferOccupancyDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, "", "buffer_occupancy_ratio"),
+		"Fraction of the shared ring buffer currently in use.",
+		nil, nil,
+	)
+)
+
+// Collector adapts a Client's engine telemetry to the prometheus.Collector
+// interface. Register it with a standard registry to expose it on a /metrics
+// endpoint:
+//
+//	registry.MustRegister(client.Collector())
+type Collector struct {
+	client *Client
+}
+
+// Collector returns a prometheus.Collector backed by this Client's engine.
+func (c *Client) Collector() *Collector {
+	return &Collector{client: c}
+}
+
+// Describe implements prometheus.Collector.
+func (col *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pluginLatencyDesc
+	ch <- pluginFuelDesc
+	ch <- bufferOccupancyDesc
+}
+
+// Collect implements prometheus.Collector, fetching a fresh snapshot from the
+// engine on every scrape.
+func (col *Collector) Collect(ch chan<- prometheus.Metric) {
+	c := col.client
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return
+	}
+
+	const maxPlugins = 256
+	cMetrics := make([]C.ZenithPluginMetric, maxPlugins)
+	var count C.size_t
+	var occupancy C.double
+
+	ret := C.zenith_get_metrics(c.enginePtr, &cMetrics[0], C.size_t(maxPlugins), &count, &occupancy)
+	if ret != C.ZENITH_OK {
+		return
+	}
+
+	ch <- prometheus.MustNewConstMetric(bufferOccupancyDesc, prometheus.GaugeValue, float64(occupancy))
+
+	for i := 0; i < int(count); i++ {
+		m := cMetrics[i]
+		id := uint64(m.plugin_id)
+		name := C.GoString(&m.plugin_name[0])
+		labels := []string{strconv.FormatUint(id, 10), name}
+
+		buckets := make(map[float64]uint64, len(m.latency_bucket_bounds))
+		for b := 0; b < len(m.latency_bucket_bounds); b++ {
+			buckets[float64(m.latency_bucket_bounds[b])] = uint64(m.latency_bucket_counts[b])
+		}
+
+		hist, err := prometheus.NewConstHistogram(
+			pluginLatencyDesc,
+			uint64(m.latency_sample_count),
+			float64(m.latency_sum_seconds),
+			buckets,
+			labels...,
+		)
+		if err == nil {
+			ch <- hist
+		}
+
+		ch <- prometheus.MustNewConstMetric(pluginFuelDesc, prometheus.CounterValue, float64(m.fuel_consumed), labels...)
+	}
+}
+
+// SetTracer configures the OpenTelemetry tracer used to instrument every
+// PushEvent call and plugin load with a span. Without a configured tracer,
+// calls use the global TracerProvider, which is a no-op until the
+// application installs one.
+func (c *Client) SetTracer(tracer trace.Tracer) {
+	c.tracerMu.Lock()
+	defer c.tracerMu.Unlock()
+	c.tracer = tracer
+}
+
+// tracerOrDefault returns the configured tracer, falling back to the global
+// TracerProvider's "zenith-dataplane" tracer.
+func (c *Client) tracerOrDefault() trace.Tracer {
+	c.tracerMu.RLock()
+	defer c.tracerMu.RUnlock()
+	if c.tracer != nil {
+		return c.tracer
+	}
+	return otel.Tracer("zenith-dataplane")
+}
@@ -0,0 +1,101 @@
+package zenith
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+func TestCollectorDescribe(t *testing.T) {
+	client := newTestClient(t)
+
+	descs := make(chan *prometheus.Desc, 8)
+	client.Collector().Describe(descs)
+	close(descs)
+
+	count := 0
+	for range descs {
+		count++
+	}
+	if count == 0 {
+		t.Error("expected at least one metric descriptor")
+	}
+}
+
+func TestCollectorCollectEmitsBufferOccupancy(t *testing.T) {
+	client := newTestClient(t)
+
+	metrics := make(chan prometheus.Metric, 32)
+	client.Collector().Collect(metrics)
+	close(metrics)
+
+	var sawBufferOccupancy bool
+	for m := range metrics {
+		if m.Desc() == bufferOccupancyDesc {
+			sawBufferOccupancy = true
+		}
+	}
+	if !sawBufferOccupancy {
+		t.Error("expected Collect to emit the buffer occupancy gauge")
+	}
+}
+
+func TestCollectorCollectOnClosedClientIsNoop(t *testing.T) {
+	client := newTestClient(t)
+	client.Close(context.Background())
+
+	metrics := make(chan prometheus.Metric, 8)
+	client.Collector().Collect(metrics)
+	close(metrics)
+
+	for range metrics {
+		t.Error("expected no metrics from a closed client's Collector")
+	}
+}
+
+func TestSetTracerOverridesDefault(t *testing.T) {
+	client := newTestClient(t)
+
+	tracer := noop.NewTracerProvider().Tracer("test")
+	client.SetTracer(tracer)
+
+	if client.tracerOrDefault() != tracer {
+		t.Error("expected tracerOrDefault to return the configured tracer")
+	}
+}
+
+func TestPushEventRecordsSpanAttributes(t *testing.T) {
+	client := newTestClient(t)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	client.SetTracer(provider.Tracer("test"))
+
+	payload := []byte("payload")
+	if err := client.PushEvent(context.Background(), "orders", payload); err != nil {
+		t.Fatalf("PushEvent failed: %v", err)
+	}
+	if err := provider.ForceFlush(context.Background()); err != nil {
+		t.Fatalf("ForceFlush failed: %v", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+
+	attrs := make(map[string]string)
+	for _, kv := range spans[0].Attributes {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	if attrs["zenith.topic"] != "orders" {
+		t.Errorf("expected zenith.topic=orders, got %q", attrs["zenith.topic"])
+	}
+	if attrs["zenith.event.bytes"] != "7" {
+		t.Errorf("expected zenith.event.bytes=7, got %q", attrs["zenith.event.bytes"])
+	}
+}
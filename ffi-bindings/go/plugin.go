@@ -0,0 +1,246 @@
+package zenith
+
+/*
+#include "../../zenith_core.h"
+#include <stdlib.h>
+*/
+import "C"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unsafe"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+// PluginHandle identifies a loaded plugin for the lifetime of its engine.
+// The zero value is not a valid handle.
+type PluginHandle struct {
+	id uint64
+}
+
+// PluginConfig bounds the resources a loaded plugin may consume.
+type PluginConfig struct {
+	// MemoryLimitBytes caps the WASM linear memory available to the plugin.
+	// Zero means the engine default.
+	MemoryLimitBytes uint64
+	// FuelLimit caps the instruction budget per invocation, preventing a
+	// runaway plugin from hanging the engine. Zero means unmetered.
+	FuelLimit uint64
+	// AllowedHostImports restricts which host functions the plugin may call.
+	// A nil slice means the engine default allow-list.
+	AllowedHostImports []string
+}
+
+// PluginInfo describes a currently loaded plugin, as returned by ListPlugins.
+type PluginInfo struct {
+	Handle           PluginHandle
+	Name             string
+	MemoryLimitBytes uint64
+	FuelLimit        uint64
+}
+
+// PluginStats reports runtime counters for a single loaded plugin.
+type PluginStats struct {
+	EventsProcessed uint64
+	ErrorCount      uint64
+	CPUTime         time.Duration
+	MemoryBytes     uint64
+}
+
+func toCPluginConfig(cfg *PluginConfig) (C.ZenithPluginConfig, func()) {
+	if cfg == nil {
+		return C.ZenithPluginConfig{}, func() {}
+	}
+
+	var cImports **C.char
+	var cleanup = func() {}
+	if n := len(cfg.AllowedHostImports); n > 0 {
+		imports := C.malloc(C.size_t(n) * C.size_t(unsafe.Sizeof(cImports)))
+		importSlice := unsafe.Slice((**C.char)(imports), n)
+		for i, name := range cfg.AllowedHostImports {
+			importSlice[i] = C.CString(name)
+		}
+		cImports = (**C.char)(imports)
+		cleanup = func() {
+			for _, p := range importSlice {
+				C.free(unsafe.Pointer(p))
+			}
+			C.free(imports)
+		}
+	}
+
+	return C.ZenithPluginConfig{
+		memory_limit_bytes:       C.uint64_t(cfg.MemoryLimitBytes),
+		fuel_limit:               C.uint64_t(cfg.FuelLimit),
+		allowed_host_imports:     cImports,
+		allowed_host_imports_len: C.size_t(len(cfg.AllowedHostImports)),
+	}, cleanup
+}
+
+// LoadPlugin loads a WASM plugin from bytes, applying cfg if non-nil, and
+// returns a handle identifying the running instance.
+func (c *Client) LoadPlugin(ctx context.Context, wasmBytes []byte, cfg *PluginConfig) (handle PluginHandle, err error) {
+	ctx, span := c.tracerOrDefault().Start(ctx, "zenith.load_plugin")
+	defer func() {
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			span.SetAttributes(attribute.Int64("zenith.plugin.id", int64(handle.id)))
+		}
+		span.End()
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return PluginHandle{}, err
+	}
+	if len(wasmBytes) == 0 {
+		return PluginHandle{}, errors.New("empty WASM bytes")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return PluginHandle{}, errors.New("client is closed")
+	}
+
+	cBytes := (*C.uint8_t)(unsafe.Pointer(&wasmBytes[0]))
+	cLen := C.size_t(len(wasmBytes))
+
+	cCfg, cleanup := toCPluginConfig(cfg)
+	defer cleanup()
+
+	var id C.uint64_t
+	ret := c.withCancel(ctx, func(opID C.uint64_t) C.int {
+		return C.zenith_load_plugin(c.enginePtr, opID, cBytes, cLen, &cCfg, &id)
+	})
+	if ret != C.ZENITH_OK {
+		return PluginHandle{}, fmt.Errorf("failed to load plugin: error code %d", ret)
+	}
+
+	return PluginHandle{id: uint64(id)}, nil
+}
+
+// LoadPluginFromFile loads a WASM plugin from a file, applying cfg if non-nil.
+func (c *Client) LoadPluginFromFile(ctx context.Context, filepath string, cfg *PluginConfig) (PluginHandle, error) {
+	wasmBytes, err := readFile(filepath)
+	if err != nil {
+		return PluginHandle{}, fmt.Errorf("failed to read plugin file: %w", err)
+	}
+
+	return c.LoadPlugin(ctx, wasmBytes, cfg)
+}
+
+// UnloadPlugin stops and removes a previously loaded plugin.
+func (c *Client) UnloadPlugin(ctx context.Context, h PluginHandle) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errors.New("client is closed")
+	}
+
+	ret := C.zenith_unload_plugin(c.enginePtr, C.uint64_t(h.id))
+	if ret != C.ZENITH_OK {
+		return fmt.Errorf("failed to unload plugin %d: error code %d", h.id, ret)
+	}
+
+	return nil
+}
+
+// ListPlugins returns every plugin currently loaded in the engine.
+func (c *Client) ListPlugins(ctx context.Context) ([]PluginInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return nil, errors.New("client is closed")
+	}
+
+	const maxPlugins = 256
+	cInfos := make([]C.ZenithPluginInfo, maxPlugins)
+	var count C.size_t
+
+	ret := C.zenith_list_plugins(c.enginePtr, &cInfos[0], C.size_t(maxPlugins), &count)
+	if ret != C.ZENITH_OK {
+		return nil, fmt.Errorf("failed to list plugins: error code %d", ret)
+	}
+
+	infos := make([]PluginInfo, count)
+	for i := 0; i < int(count); i++ {
+		infos[i] = PluginInfo{
+			Handle:           PluginHandle{id: uint64(cInfos[i].id)},
+			Name:             C.GoString(&cInfos[i].name[0]),
+			MemoryLimitBytes: uint64(cInfos[i].memory_limit_bytes),
+			FuelLimit:        uint64(cInfos[i].fuel_limit),
+		}
+	}
+
+	return infos, nil
+}
+
+// ReloadPlugin atomically swaps the WASM module behind h for wasmBytes. The
+// engine drains events already in flight for the old instance before the new
+// one starts receiving traffic, so no event is delivered to a half-replaced
+// plugin.
+func (c *Client) ReloadPlugin(ctx context.Context, h PluginHandle, wasmBytes []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(wasmBytes) == 0 {
+		return errors.New("empty WASM bytes")
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return errors.New("client is closed")
+	}
+
+	cBytes := (*C.uint8_t)(unsafe.Pointer(&wasmBytes[0]))
+	cLen := C.size_t(len(wasmBytes))
+
+	ret := c.withCancel(ctx, func(opID C.uint64_t) C.int {
+		return C.zenith_reload_plugin(c.enginePtr, opID, C.uint64_t(h.id), cBytes, cLen)
+	})
+	if ret != C.ZENITH_OK {
+		return fmt.Errorf("failed to reload plugin %d: error code %d", h.id, ret)
+	}
+
+	return nil
+}
+
+// PluginStats retrieves runtime counters for a single loaded plugin.
+func (c *Client) PluginStats(ctx context.Context, h PluginHandle) (*PluginStats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if c.closed {
+		return nil, errors.New("client is closed")
+	}
+
+	var cStats C.ZenithPluginStats
+	ret := C.zenith_plugin_stats(c.enginePtr, C.uint64_t(h.id), &cStats)
+	if ret != C.ZENITH_OK {
+		return nil, fmt.Errorf("failed to get plugin stats for %d: error code %d", h.id, ret)
+	}
+
+	return &PluginStats{
+		EventsProcessed: uint64(cStats.events_processed),
+		ErrorCount:      uint64(cStats.error_count),
+		CPUTime:         time.Duration(cStats.cpu_time_nanos),
+		MemoryBytes:     uint64(cStats.memory_bytes),
+	}, nil
+}
@@ -0,0 +1,130 @@
+package zenith
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLoadPluginRejectsEmptyBytes(t *testing.T) {
+	client := newTestClient(t)
+
+	if _, err := client.LoadPlugin(context.Background(), nil, nil); err == nil {
+		t.Error("expected error for empty WASM bytes")
+	}
+}
+
+func TestUnloadPluginOnClosedClient(t *testing.T) {
+	client := newTestClient(t)
+	client.Close(context.Background())
+
+	if err := client.UnloadPlugin(context.Background(), PluginHandle{}); err == nil {
+		t.Error("expected error when client is closed")
+	}
+}
+
+func TestListPluginsReflectsLoadedPlugin(t *testing.T) {
+	client := newTestClient(t)
+
+	cfg := &PluginConfig{MemoryLimitBytes: 1 << 20, FuelLimit: 1000}
+	handle, err := client.LoadPlugin(context.Background(), []byte("wasm bytes"), cfg)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+
+	infos, err := client.ListPlugins(context.Background())
+	if err != nil {
+		t.Fatalf("ListPlugins failed: %v", err)
+	}
+
+	var found *PluginInfo
+	for i := range infos {
+		if infos[i].Handle == handle {
+			found = &infos[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("expected loaded plugin %+v in %+v", handle, infos)
+	}
+	if found.MemoryLimitBytes != cfg.MemoryLimitBytes {
+		t.Errorf("expected memory limit %d, got %d", cfg.MemoryLimitBytes, found.MemoryLimitBytes)
+	}
+	if found.FuelLimit != cfg.FuelLimit {
+		t.Errorf("expected fuel limit %d, got %d", cfg.FuelLimit, found.FuelLimit)
+	}
+}
+
+func TestReloadPluginKeepsHandleStable(t *testing.T) {
+	client := newTestClient(t)
+
+	handle, err := client.LoadPlugin(context.Background(), []byte("wasm v1"), nil)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+
+	if err := client.ReloadPlugin(context.Background(), handle, []byte("wasm v2")); err != nil {
+		t.Fatalf("ReloadPlugin failed: %v", err)
+	}
+
+	infos, err := client.ListPlugins(context.Background())
+	if err != nil {
+		t.Fatalf("ListPlugins failed: %v", err)
+	}
+	for _, info := range infos {
+		if info.Handle == handle {
+			return
+		}
+	}
+	t.Errorf("expected reloaded plugin to keep handle %+v", handle)
+}
+
+func TestReloadPluginRejectsEmptyBytes(t *testing.T) {
+	client := newTestClient(t)
+
+	handle, err := client.LoadPlugin(context.Background(), []byte("wasm v1"), nil)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+
+	if err := client.ReloadPlugin(context.Background(), handle, nil); err == nil {
+		t.Error("expected error for empty WASM bytes")
+	}
+}
+
+func TestPluginStatsAfterLoad(t *testing.T) {
+	client := newTestClient(t)
+
+	handle, err := client.LoadPlugin(context.Background(), []byte("wasm bytes"), nil)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+
+	stats, err := client.PluginStats(context.Background(), handle)
+	if err != nil {
+		t.Fatalf("PluginStats failed: %v", err)
+	}
+	if stats == nil {
+		t.Fatal("expected non-nil stats for a loaded plugin")
+	}
+}
+
+func TestUnloadPluginRemovesFromList(t *testing.T) {
+	client := newTestClient(t)
+
+	handle, err := client.LoadPlugin(context.Background(), []byte("wasm bytes"), nil)
+	if err != nil {
+		t.Fatalf("LoadPlugin failed: %v", err)
+	}
+	if err := client.UnloadPlugin(context.Background(), handle); err != nil {
+		t.Fatalf("UnloadPlugin failed: %v", err)
+	}
+
+	infos, err := client.ListPlugins(context.Background())
+	if err != nil {
+		t.Fatalf("ListPlugins failed: %v", err)
+	}
+	for _, info := range infos {
+		if info.Handle == handle {
+			t.Errorf("expected unloaded plugin %+v to be absent from %+v", handle, infos)
+		}
+	}
+}
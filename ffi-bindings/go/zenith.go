@@ -7,92 +7,163 @@ package zenith
 */
 import "C"
 import (
+	"context"
 	"errors"
 	"fmt"
-	"unsafe"
+	"os"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
-// Client represents a Zenith engine instance
+// Client represents a Zenith engine instance. A Client is safe for
+// concurrent use by multiple goroutines.
 type Client struct {
+	// mu guards enginePtr and closed against a concurrent Close freeing the
+	// engine out from under an in-flight call. Calls take the read lock so
+	// they can run concurrently with each other but never with the brief
+	// write-lock window in which Close actually frees enginePtr.
+	mu        sync.RWMutex
 	enginePtr C.ZenithEngine
 	closed    bool
+
+	// closeOnce and closeSignal make Close idempotent and let it wake up
+	// every long-lived background goroutine (the drain loop, each
+	// Subscribe's per-call watcher) without holding mu for the whole
+	// shutdown: those goroutines can take arbitrarily long to notice, and
+	// mu must stay free in the meantime or every other method blocks too.
+	closeOnce   sync.Once
+	closeSignal chan struct{}
+
+	nextOpID uint64
+
+	// subsMu guards subs, drainStop, and drainDone, which together with
+	// drainOnce back Subscribe's single shared ring-buffer drain goroutine.
+	subsMu    sync.Mutex
+	subs      map[string][]*subscription
+	drainOnce sync.Once
+	drainStop chan struct{}
+	drainDone chan struct{}
+
+	// tracerMu guards tracer, set via SetTracer and read on every traced call.
+	tracerMu sync.RWMutex
+	tracer   trace.Tracer
 }
 
-// NewClient creates a new Zenith client with specified buffer size
-func NewClient(bufferSize uint32) (*Client, error) {
+// NewClient creates a new Zenith client with specified buffer size.
+func NewClient(ctx context.Context, bufferSize uint32) (*Client, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
 	ptr := C.zenith_init(C.uint32_t(bufferSize))
 	if ptr == nil {
 		return nil, errors.New("failed to initialize Zenith engine")
 	}
-	
-	return &Client{
-		enginePtr: ptr,
-		closed:    false,
-	}, nil
-}
 
-// LoadPlugin loads a WASM plugin from bytes
-func (c *Client) LoadPlugin(wasmBytes []byte) error {
-	if c.closed {
-		return errors.New("client is closed")
-	}
-	
-	if len(wasmBytes) == 0 {
-		return errors.New("empty WASM bytes")
-	}
-	
-	cBytes := (*C.uint8_t)(unsafe.Pointer(&wasmBytes[0]))
-	cLen := C.size_t(len(wasmBytes))
-	
-	ret := C.zenith_load_plugin(c.enginePtr, cBytes, cLen)
-	if ret != C.ZENITH_OK {
-		return fmt.Errorf("failed to load plugin: error code %d", ret)
+	c := &Client{
+		enginePtr:   ptr,
+		closed:      false,
+		closeSignal: make(chan struct{}),
 	}
-	
-	return nil
+	runtime.SetFinalizer(c, (*Client).finalize)
+
+	return c, nil
 }
 
-// LoadPluginFromFile loads a WASM plugin from file
-func (c *Client) LoadPluginFromFile(filepath string) error {
-	wasmBytes, err := readFile(filepath)
-	if err != nil {
-		return fmt.Errorf("failed to read plugin file: %w", err)
+// GetStats retrieves engine statistics.
+func (c *Client) GetStats(ctx context.Context) (*Stats, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	
-	return c.LoadPlugin(wasmBytes)
-}
 
-// GetStats retrieves engine statistics
-func (c *Client) GetStats() (*Stats, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
 	if c.closed {
 		return nil, errors.New("client is closed")
 	}
-	
+
 	var cStats C.ZenithStats
 	ret := C.zenith_get_stats(c.enginePtr, &cStats)
 	if ret != C.ZENITH_OK {
 		return nil, fmt.Errorf("failed to get stats: error code %d", ret)
 	}
-	
+
 	return &Stats{
-		BufferLen:        uint64(cStats.buffer_len),
-		PluginCount:      uint64(cStats.plugin_count),
-		EventsProcessed:  uint64(cStats.events_processed),
+		BufferLen:       uint64(cStats.buffer_len),
+		PluginCount:     uint64(cStats.plugin_count),
+		EventsProcessed: uint64(cStats.events_processed),
 	}, nil
 }
 
-// Close frees the engine resources
-func (c *Client) Close() error {
-	if c.closed {
+// Close frees the engine resources. Close is idempotent and safe to call
+// concurrently with itself and with any other Client method. It does not
+// hold the write lock for its entire body: shutting down the drain loop and
+// every Subscribe watcher can take a while (a BackpressureBlock subscriber
+// that has stopped reading makes the drain loop wait), and holding mu for
+// that whole window would stall every other method behind it. The write
+// lock is only taken for the moment enginePtr is actually freed.
+func (c *Client) Close(ctx context.Context) error {
+	c.mu.RLock()
+	alreadyClosed := c.closed
+	c.mu.RUnlock()
+	if alreadyClosed {
 		return nil
 	}
-	
-	C.zenith_free(c.enginePtr)
-	c.closed = true
+
+	c.closeOnce.Do(func() {
+		close(c.closeSignal)
+		c.stopDrainLoop()
+		c.closeAllSubscriptions()
+
+		c.mu.Lock()
+		C.zenith_free(c.enginePtr)
+		c.closed = true
+		runtime.SetFinalizer(c, nil)
+		c.mu.Unlock()
+	})
 	return nil
 }
 
-// Stats represents engine statistics
+// finalize runs if the owner forgets to call Close and the Client becomes
+// unreachable. The drain loop and each Subscribe watcher hold a reference to
+// the Client, so a Client with an active subscription is not finalized.
+func (c *Client) finalize() {
+	_ = c.Close(context.Background())
+}
+
+// nextOp allocates a new operation ID for a cancelable CGo call.
+func (c *Client) nextOp() C.uint64_t {
+	return C.uint64_t(atomic.AddUint64(&c.nextOpID, 1))
+}
+
+// withCancel runs fn with a freshly allocated operation ID, watching ctx in
+// the background and invoking zenith_cancel for that ID if ctx is done before
+// fn returns. Use this for calls long enough that the Rust side can act on a
+// mid-flight cancellation; short calls should just check ctx.Err() up front.
+func (c *Client) withCancel(ctx context.Context, fn func(opID C.uint64_t) C.int) C.int {
+	opID := c.nextOp()
+
+	if ctx.Done() == nil {
+		return fn(opID)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			C.zenith_cancel(c.enginePtr, opID)
+		case <-done:
+		}
+	}()
+
+	return fn(opID)
+}
+
+// Stats represents engine statistics.
 type Stats struct {
 	BufferLen       uint64
 	PluginCount     uint64
@@ -101,13 +172,9 @@ type Stats struct {
 
 // Helper function to read file
 func readFile(filepath string) ([]byte, error) {
-	// Using standard library
 	data, err := os.ReadFile(filepath)
 	if err != nil {
 		return nil, err
 	}
 	return data, nil
 }
-
-// Import os package
-import "os"
@@ -1,15 +1,26 @@
 package zenith
 
 import (
+	"context"
 	"testing"
 )
 
-func TestClientCreation(t *testing.T) {
-	client, err := NewClient(1024)
+// newTestClient creates a Client against a 1024-byte buffer and registers a
+// cleanup to close it, so individual tests don't each repeat that setup.
+func newTestClient(t *testing.T) *Client {
+	t.Helper()
+
+	client, err := NewClient(context.Background(), 1024)
 	if err != nil {
 		t.Fatalf("Failed to create client: %v", err)
 	}
-	defer client.Close()
+	t.Cleanup(func() { client.Close(context.Background()) })
+
+	return client
+}
+
+func TestClientCreation(t *testing.T) {
+	client := newTestClient(t)
 
 	if client.closed {
 		t.Error("Client should not be closed after creation")
@@ -17,13 +28,9 @@ func TestClientCreation(t *testing.T) {
 }
 
 func TestClientClose(t *testing.T) {
-	client, err := NewClient(1024)
-	if err != nil {
-		t.Fatalf("Failed to create client: %v", err)
-	}
+	client := newTestClient(t)
 
-	err = client.Close()
-	if err != nil {
+	if err := client.Close(context.Background()); err != nil {
 		t.Errorf("Close failed: %v", err)
 	}
 
@@ -32,20 +39,53 @@ func TestClientClose(t *testing.T) {
 	}
 
 	// Double close should be safe
-	err = client.Close()
-	if err != nil {
+	if err := client.Close(context.Background()); err != nil {
 		t.Errorf("Second close failed: %v", err)
 	}
 }
 
-func TestGetStats(t *testing.T) {
-	client, err := NewClient(1024)
+func TestCloseWaitsForDrainLoopBeforeFreeingEngine(t *testing.T) {
+	client := newTestClient(t)
+
+	if _, err := client.Subscribe(context.Background(), "orders"); err != nil {
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+
+	// If Close returned before drainLoop actually exited, drainDone would
+	// still be open and the non-blocking receive below would fall through
+	// to the default case and fail the test.
+	select {
+	case <-client.drainDone:
+	default:
+		t.Error("expected drainLoop to have exited by the time Close returns")
+	}
+}
+
+func TestCloseClosesOpenSubscriptions(t *testing.T) {
+	client := newTestClient(t)
+
+	ch, err := client.Subscribe(context.Background(), "orders")
 	if err != nil {
-		t.Fatalf("Failed to create client: %v", err)
+		t.Fatalf("Subscribe failed: %v", err)
+	}
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+
+	if _, ok := <-ch; ok {
+		t.Error("expected subscription channel to be closed after Close")
 	}
-	defer client.Close()
+}
 
-	stats, err := client.GetStats()
+func TestGetStats(t *testing.T) {
+	client := newTestClient(t)
+
+	stats, err := client.GetStats(context.Background())
 	if err != nil {
 		t.Errorf("GetStats failed: %v", err)
 	}
@@ -54,3 +94,14 @@ func TestGetStats(t *testing.T) {
 		t.Error("Stats should not be nil")
 	}
 }
+
+func TestGetStatsRejectsCanceledContext(t *testing.T) {
+	client := newTestClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := client.GetStats(ctx); err == nil {
+		t.Error("expected error for canceled context")
+	}
+}